@@ -0,0 +1,49 @@
+// Package config carries flag- and env-derived settings through context, so
+// deeply-nested helpers (internal/prompt in particular) don't need every
+// setting threaded explicitly through their signatures.
+package config
+
+import "context"
+
+// Config holds settings read from global and command flags. Organization
+// and Region are populated elsewhere by the existing --org/--region flags;
+// fields added here back flags bound via BindFlags.
+type Config struct {
+	Organization string
+	Region       string
+
+	// NoFuzzy disables fuzzy filtering on Select/MultiSelect prompts,
+	// falling back to survey's default prefix filter. Bound to --no-fuzzy.
+	NoFuzzy bool
+
+	// AnswersFile points at a YAML/JSON file of key -> answer pairs
+	// consulted before any interactive prompt runs. Bound to
+	// --answers-file.
+	AnswersFile string
+
+	// SaveAnswers appends each interactively-given answer back to
+	// AnswersFile so the session can be replayed non-interactively later.
+	// Bound to --save-answers.
+	SaveAnswers bool
+
+	// ProbeRegions enables latency probing and continent grouping in the
+	// region picker. Bound to --probe-regions.
+	ProbeRegions bool
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying cfg.
+func NewContext(ctx context.Context, cfg *Config) context.Context {
+	return context.WithValue(ctx, contextKey{}, cfg)
+}
+
+// FromContext returns the Config stored in ctx, or a zero-value Config if
+// none was set, so callers (and tests) that don't wire one up still get
+// sane defaults instead of a nil dereference.
+func FromContext(ctx context.Context) *Config {
+	if cfg, ok := ctx.Value(contextKey{}).(*Config); ok {
+		return cfg
+	}
+	return &Config{}
+}
@@ -0,0 +1,24 @@
+package config
+
+import "github.com/spf13/pflag"
+
+// Flag names for the settings BindFlags registers, exported so commands can
+// reference them (e.g. in flag help or when checking cmd.Flags().Changed).
+const (
+	FlagNoFuzzy      = "no-fuzzy"
+	FlagAnswersFile  = "answers-file"
+	FlagSaveAnswers  = "save-answers"
+	FlagProbeRegions = "probe-regions"
+)
+
+// BindFlags registers the prompt-related persistent flags on flags, writing
+// parsed values directly into cfg. Commands that call into internal/prompt
+// (launch, deploy, regions, scale, ...) call this alongside their own flags
+// during setup, then store cfg in context via NewContext so prompt helpers
+// can read it back through FromContext.
+func BindFlags(flags *pflag.FlagSet, cfg *Config) {
+	flags.BoolVar(&cfg.NoFuzzy, FlagNoFuzzy, false, "disable fuzzy filtering in interactive select prompts")
+	flags.StringVar(&cfg.AnswersFile, FlagAnswersFile, "", "path to a YAML/JSON file of pre-supplied prompt answers")
+	flags.BoolVar(&cfg.SaveAnswers, FlagSaveAnswers, false, "append interactively-given answers back to --answers-file")
+	flags.BoolVar(&cfg.ProbeRegions, FlagProbeRegions, false, "probe region latency and group the region picker by continent")
+}
@@ -0,0 +1,117 @@
+package prompt
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/superfly/flyctl/internal/config"
+)
+
+// answersCache holds the parsed answers file per resolved path so repeated
+// loadAnswers calls in one flyctl invocation don't re-read the same file,
+// while still reloading when a caller resolves to a different path.
+var (
+	answersCacheMu sync.Mutex
+	answersCache   = map[string]map[string]string{}
+)
+
+// answersFilePath resolves the answers file location: --answers-file,
+// FLY_ANSWERS_FILE, then $XDG_CONFIG_HOME/fly/answers.yml.
+func answersFilePath(ctx context.Context) string {
+	if p := config.FromContext(ctx).AnswersFile; p != "" {
+		return p
+	}
+	if p := os.Getenv("FLY_ANSWERS_FILE"); p != "" {
+		return p
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "fly", "answers.yml")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "fly", "answers.yml")
+	}
+	return ""
+}
+
+// loadAnswers returns the resolved answers file path for ctx and its parsed
+// contents, reading the file at most once per path per process.
+func loadAnswers(ctx context.Context) (string, map[string]string) {
+	path := answersFilePath(ctx)
+
+	answersCacheMu.Lock()
+	defer answersCacheMu.Unlock()
+
+	if cached, ok := answersCache[path]; ok {
+		return path, cached
+	}
+
+	parsed := map[string]string{}
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			if strings.HasSuffix(path, ".json") {
+				_ = json.Unmarshal(data, &parsed)
+			} else {
+				_ = yaml.Unmarshal(data, &parsed)
+			}
+		}
+	}
+	answersCache[path] = parsed
+	return path, parsed
+}
+
+// answerFor returns a pre-supplied answer for key, checking a
+// FLY_PROMPT_<KEY> environment variable before the answers file, and
+// whether one was found at all.
+func answerFor(ctx context.Context, key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+
+	if v, ok := os.LookupEnv("FLY_PROMPT_" + strings.ToUpper(key)); ok {
+		return v, true
+	}
+
+	_, answers := loadAnswers(ctx)
+	v, ok := answers[key]
+	return v, ok
+}
+
+// saveAnswer appends key/value to the answers file when --save-answers is
+// set, so an interactive session can be replayed non-interactively later.
+func saveAnswer(ctx context.Context, key, value string) {
+	if key == "" || !config.FromContext(ctx).SaveAnswers {
+		return
+	}
+
+	path, answers := loadAnswers(ctx)
+	if path == "" {
+		return
+	}
+
+	answersCacheMu.Lock()
+	answers[key] = value
+	answersCacheMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(path, ".json") {
+		data, err = json.MarshalIndent(answers, "", "  ")
+	} else {
+		data, err = yaml.Marshal(answers)
+	}
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,160 @@
+package prompt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/superfly/flyctl/internal/config"
+)
+
+func ctxWithAnswersFile(t *testing.T, path string) context.Context {
+	t.Helper()
+	return config.NewContext(context.Background(), &config.Config{AnswersFile: path})
+}
+
+func TestAnswerForPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yml")
+	data, err := yaml.Marshal(map[string]string{"region": "fra"})
+	if err != nil {
+		t.Fatalf("yaml.Marshal: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	ctx := ctxWithAnswersFile(t, path)
+
+	if _, ok := answerFor(ctx, ""); ok {
+		t.Error("expected no answer for an empty key")
+	}
+
+	t.Setenv("FLY_PROMPT_REGION", "ord")
+	if v, ok := answerFor(ctx, "region"); !ok || v != "ord" {
+		t.Fatalf("answerFor(region) = %q, %v; want ord, true (env takes precedence over the answers file)", v, ok)
+	}
+}
+
+func TestStringRequiredRejectsBlankSourcedAnswer(t *testing.T) {
+	t.Setenv("FLY_PROMPT_NAME", "")
+
+	var dst string
+	err := String(context.Background(), &dst, "name", "Name:", "", true)
+	if err == nil {
+		t.Fatal("expected an error for a blank required answer sourced from the environment")
+	}
+}
+
+func TestStringOptionalAcceptsBlankSourcedAnswer(t *testing.T) {
+	t.Setenv("FLY_PROMPT_NAME", "")
+
+	var dst string
+	if err := String(context.Background(), &dst, "name", "Name:", "default", false); err != nil {
+		t.Fatalf("unexpected error for a blank optional answer: %v", err)
+	}
+	if dst != "" {
+		t.Fatalf("dst = %q, want empty", dst)
+	}
+}
+
+func TestPasswordRequiredRejectsBlankSourcedAnswer(t *testing.T) {
+	t.Setenv("FLY_PROMPT_TOKEN", "")
+
+	var dst string
+	err := Password(context.Background(), &dst, "token", "Token:", true)
+	if err == nil {
+		t.Fatal("expected an error for a blank required answer sourced from the environment")
+	}
+}
+
+func TestTypedPromptRequiredRejectsBlankSourcedAnswer(t *testing.T) {
+	t.Setenv("FLY_PROMPT_SIZE", "")
+
+	alwaysValid := ValidatorFunc(func(string) error { return nil })
+	_, err := typedPrompt(context.Background(), "size", "Size:", "", true, "", alwaysValid)
+	if err == nil {
+		t.Fatal("expected an error for a blank required answer sourced from the environment")
+	}
+}
+
+func TestConfigFromContextDefaultsToZeroValue(t *testing.T) {
+	cfg := config.FromContext(context.Background())
+	if cfg == nil {
+		t.Fatal("expected a non-nil default Config")
+	}
+	if cfg.AnswersFile != "" || cfg.SaveAnswers {
+		t.Fatalf("expected zero-value Config, got %+v", cfg)
+	}
+}
+
+func TestAnswerForIgnoresUnsetKey(t *testing.T) {
+	_, ok := answerFor(context.Background(), "definitely-unset-key")
+	if ok {
+		t.Error("expected no answer for an unset key")
+	}
+}
+
+func TestAnswerForReadsAnswersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yml")
+	data, err := yaml.Marshal(map[string]string{"region": "fra"})
+	if err != nil {
+		t.Fatalf("yaml.Marshal: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+
+	ctx := ctxWithAnswersFile(t, path)
+	if v, ok := answerFor(ctx, "region"); !ok || v != "fra" {
+		t.Fatalf("answerFor(region) = %q, %v; want fra, true", v, ok)
+	}
+	if _, ok := answerFor(ctx, "missing"); ok {
+		t.Error("expected no answer for a key absent from the answers file")
+	}
+}
+
+func TestSaveAnswerRoundTrips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yml")
+	ctx := config.NewContext(context.Background(), &config.Config{AnswersFile: path, SaveAnswers: true})
+
+	saveAnswer(ctx, "region", "ord")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected --save-answers to create %s: %v", path, err)
+	}
+	saved := map[string]string{}
+	if err := yaml.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("yaml.Unmarshal: %v", err)
+	}
+	if saved["region"] != "ord" {
+		t.Fatalf("saved answers = %+v, want region=ord", saved)
+	}
+
+	if v, ok := answerFor(ctx, "region"); !ok || v != "ord" {
+		t.Fatalf("answerFor(region) after save = %q, %v; want ord, true", v, ok)
+	}
+}
+
+func TestLoadAnswersReloadsForDifferentPath(t *testing.T) {
+	pathA := filepath.Join(t.TempDir(), "a.yml")
+	pathB := filepath.Join(t.TempDir(), "b.yml")
+
+	dataA, _ := yaml.Marshal(map[string]string{"region": "ord"})
+	dataB, _ := yaml.Marshal(map[string]string{"region": "fra"})
+	if err := os.WriteFile(pathA, dataA, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	if err := os.WriteFile(pathB, dataB, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+
+	if v, ok := answerFor(ctxWithAnswersFile(t, pathA), "region"); !ok || v != "ord" {
+		t.Fatalf("answerFor(region) from pathA = %q, %v; want ord, true", v, ok)
+	}
+	if v, ok := answerFor(ctxWithAnswersFile(t, pathB), "region"); !ok || v != "fra" {
+		t.Fatalf("answerFor(region) from pathB = %q, %v; want fra, true (got stale cache from another path)", v, ok)
+	}
+}
@@ -0,0 +1,211 @@
+package prompt
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/superfly/flyctl/internal/config"
+)
+
+// SelectOption is a single choice presented to the user. Help, when set, is
+// shown when the user presses "?" on the option. SearchKeys are additional
+// terms (region codes, org slugs, etc.) that fuzzy matching considers
+// alongside Label, but that are never rendered.
+type SelectOption struct {
+	Label      string
+	Help       string
+	SearchKeys []string
+}
+
+// SelectConfig configures a Select/MultiSelect prompt. It's the typed
+// replacement for the variadic-string Select/MultiSelect API; Select and
+// MultiSelect remain as thin wrappers around it for existing callers.
+type SelectConfig struct {
+	Message  string
+	Default  string
+	PageSize int
+	Options  []SelectOption
+}
+
+func (c *SelectConfig) pageSize() int {
+	if c.PageSize > 0 {
+		return c.PageSize
+	}
+	return 15
+}
+
+func (c *SelectConfig) labels() []string {
+	labels := make([]string, len(c.Options))
+	for i, opt := range c.Options {
+		labels[i] = opt.Label
+	}
+	return labels
+}
+
+func (c *SelectConfig) helpFor(label string) string {
+	for _, opt := range c.Options {
+		if opt.Label == label {
+			return opt.Help
+		}
+	}
+	return ""
+}
+
+// useFuzzy reports whether fuzzy filtering should be used for this prompt,
+// honoring the --no-fuzzy override (config.Config.NoFuzzy).
+func useFuzzy(ctx context.Context) bool {
+	return !config.FromContext(ctx).NoFuzzy
+}
+
+// FuzzySelect prompts the user to pick one of cfg.Options. Typed input is
+// matched as a case-insensitive fuzzy subsequence against each option's
+// Label and SearchKeys, with matches ranked by fuzzyScore (tightest match
+// first): survey.Select.Filter can't do this itself (it's a boolean
+// include/exclude predicate that preserves the original option order), so
+// the fuzzy path uses rankedSelect instead of survey.Select. Set --no-fuzzy
+// to fall back to plain survey.Select and its default prefix filter.
+func FuzzySelect(ctx context.Context, index *int, cfg *SelectConfig) error {
+	start := time.Now()
+
+	opt, err := newSurveyIO(ctx)
+	if err != nil {
+		return err
+	}
+
+	var p survey.Prompt
+	if useFuzzy(ctx) {
+		p = newRankedSelect(cfg)
+	} else {
+		sel := &survey.Select{
+			Message:  cfg.Message,
+			Options:  cfg.labels(),
+			PageSize: cfg.pageSize(),
+			Description: func(value string, _ int) string {
+				return cfg.helpFor(value)
+			},
+		}
+		if cfg.Default != "" {
+			sel.Default = cfg.Default
+		}
+		p = sel
+	}
+
+	if err := survey.AskOne(p, index, opt); err != nil {
+		return err
+	}
+
+	var answer string
+	if *index >= 0 && *index < len(cfg.Options) {
+		answer = cfg.Options[*index].Label
+	}
+	recordTranscript(ctx, "select", cfg.Message, cfg.Default, cfg.labels(), answer, start)
+
+	return nil
+}
+
+// FuzzyMultiSelect is the MultiSelect analog of FuzzySelect. Unlike
+// FuzzySelect it still filters via survey.MultiSelect.Filter, so matches
+// keep their original relative order rather than being ranked by score:
+// survey.MultiSelect has no equivalent of rankedSelect yet.
+func FuzzyMultiSelect(ctx context.Context, indices *[]int, cfg *SelectConfig, def []int) error {
+	start := time.Now()
+
+	opt, err := newSurveyIO(ctx)
+	if err != nil {
+		return err
+	}
+
+	p := &survey.MultiSelect{
+		Message:  cfg.Message,
+		Options:  cfg.labels(),
+		PageSize: cfg.pageSize(),
+		Default:  def,
+		Description: func(value string, _ int) string {
+			return cfg.helpFor(value)
+		},
+	}
+	if useFuzzy(ctx) {
+		p.Filter = fuzzyFilter(cfg)
+	}
+
+	if err := survey.AskOne(p, indices, opt); err != nil {
+		return err
+	}
+	recordTranscript(ctx, "multiselect", cfg.Message, "", cfg.labels(), multiSelectAnswer(cfg, *indices), start)
+
+	return nil
+}
+
+// multiSelectAnswer renders the labels of the selected indices as a single
+// comma-separated string for the transcript's Answer field.
+func multiSelectAnswer(cfg *SelectConfig, indices []int) string {
+	var answers []string
+	for _, i := range indices {
+		if i >= 0 && i < len(cfg.Options) {
+			answers = append(answers, cfg.Options[i].Label)
+		}
+	}
+	return strings.Join(answers, ", ")
+}
+
+// fuzzyFilter builds a survey filter that matches filterValue against an
+// option's label plus its SearchKeys using fuzzyScore as a subsequence
+// match test. It backs FuzzyMultiSelect; survey calls the filter once per
+// rendered row and only consumes a bool, so it can't rank matches the way
+// rankedSelect does for FuzzySelect.
+func fuzzyFilter(cfg *SelectConfig) func(filterValue, optValue string, index int) bool {
+	return func(filterValue, optValue string, index int) bool {
+		if filterValue == "" {
+			return true
+		}
+
+		candidates := []string{optValue}
+		if index < len(cfg.Options) {
+			candidates = append(candidates, cfg.Options[index].SearchKeys...)
+		}
+
+		for _, c := range candidates {
+			if fuzzyScore(filterValue, c) >= 0 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// fuzzyScore returns a non-negative match score for query as a subsequence
+// of candidate (lower is a tighter match), or -1 if query doesn't match.
+func fuzzyScore(query, candidate string) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	candidate = strings.ToLower(candidate)
+	if query == "" {
+		return 0
+	}
+
+	qi := 0
+	score := 0
+	firstMatch := -1
+	lastMatch := -1
+	for i := 0; i < len(candidate) && qi < len(query); i++ {
+		if candidate[i] == query[qi] {
+			if lastMatch >= 0 {
+				score += i - lastMatch - 1
+			} else {
+				firstMatch = i
+			}
+			lastMatch = i
+			qi++
+		}
+	}
+	if qi < len(query) {
+		return -1
+	}
+	// Count the offset before the first matched character too, not just
+	// gaps between matches, so a match at the very start of candidate beats
+	// an equally tight match buried deeper in it (e.g. an exact region code
+	// ranks above a longer label that merely contains it as a substring).
+	return score + firstMatch
+}
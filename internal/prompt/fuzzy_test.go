@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/superfly/flyctl/internal/config"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		candidate string
+		wantMatch bool
+	}{
+		{"empty query matches anything", "", "ord", true},
+		{"exact match", "ord", "ord", true},
+		{"subsequence match", "od", "ord", true},
+		{"case insensitive", "ORD", "ord", true},
+		{"out of order fails", "do", "ord", false},
+		{"missing characters fail", "xyz", "ord", false},
+		{"match inside a longer candidate", "ord", "bordeaux", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			score := fuzzyScore(c.query, c.candidate)
+			if match := score >= 0; match != c.wantMatch {
+				t.Fatalf("fuzzyScore(%q, %q) = %d, want match=%v", c.query, c.candidate, score, c.wantMatch)
+			}
+		})
+	}
+}
+
+func TestFuzzyScoreTighterMatchesScoreLower(t *testing.T) {
+	// "ord" matches "ord-city" contiguously (score 0) and "o-r-d" with gaps
+	// (higher score); fuzzyScore should prefer the former. rankedSelect
+	// uses this ordering to rank FuzzySelect's rendered rows.
+	tight := fuzzyScore("ord", "ord-city")
+	loose := fuzzyScore("ord", "o-r-d-city")
+	if !(tight < loose) {
+		t.Fatalf("want tight match score (%d) < loose match score (%d)", tight, loose)
+	}
+}
+
+func TestFuzzyScorePrefersEarlierMatch(t *testing.T) {
+	// Both are equally tight contiguous matches for "ord", but the second
+	// one only appears after a long prefix; an exact match at the start of
+	// a candidate should outrank the same substring buried deeper in one.
+	leading := fuzzyScore("ord", "ord-city")
+	buried := fuzzyScore("ord", "zzzzzzzzzzzzord")
+	if !(leading < buried) {
+		t.Fatalf("want leading match score (%d) < buried match score (%d)", leading, buried)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	cfg := &SelectConfig{
+		Options: []SelectOption{
+			{Label: "Chicago (ord)", SearchKeys: []string{"ord", "Chicago"}},
+			{Label: "Amsterdam (ams)", SearchKeys: []string{"ams", "Amsterdam", "nl"}},
+		},
+	}
+	filter := fuzzyFilter(cfg)
+
+	if !filter("ord", cfg.Options[0].Label, 0) {
+		t.Error("expected label match to pass")
+	}
+	// "nl" is not a subsequence of the label, only of a SearchKey.
+	if !filter("nl", cfg.Options[1].Label, 1) {
+		t.Error("expected SearchKey match to pass")
+	}
+	if filter("zzz", cfg.Options[0].Label, 0) {
+		t.Error("expected non-matching query to fail")
+	}
+	if !filter("", cfg.Options[0].Label, 0) {
+		t.Error("expected empty query to match everything")
+	}
+}
+
+func TestUseFuzzy(t *testing.T) {
+	ctx := context.Background()
+	if !useFuzzy(ctx) {
+		t.Error("expected fuzzy filtering enabled by default")
+	}
+
+	ctx = config.NewContext(ctx, &config.Config{NoFuzzy: true})
+	if useFuzzy(ctx) {
+		t.Error("expected --no-fuzzy to disable fuzzy filtering")
+	}
+}
+
+func TestMultiSelectAnswerJoinsSelectedLabels(t *testing.T) {
+	cfg := &SelectConfig{
+		Options: []SelectOption{
+			{Label: "Chicago (ord)"},
+			{Label: "Amsterdam (ams)"},
+			{Label: "Sydney (syd)"},
+		},
+	}
+
+	if got, want := multiSelectAnswer(cfg, []int{0, 2}), "Chicago (ord), Sydney (syd)"; got != want {
+		t.Errorf("multiSelectAnswer() = %q, want %q", got, want)
+	}
+	if got, want := multiSelectAnswer(cfg, nil), ""; got != want {
+		t.Errorf("multiSelectAnswer() = %q, want %q", got, want)
+	}
+}
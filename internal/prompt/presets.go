@@ -0,0 +1,115 @@
+package prompt
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/superfly/flyctl/api"
+	"github.com/superfly/flyctl/client"
+)
+
+//go:embed presets/presets.json
+var presetCatalogJSON []byte
+
+// vmSizePreset is a named shortcut to a concrete VM size, so callers can
+// shop by workload shape ("Hobby", "Small web") instead of raw size names.
+// The catalog lives in presets/presets.json so it can evolve independently
+// of the platform's VM size API.
+type vmSizePreset struct {
+	Key         string `json:"key"`
+	Label       string `json:"label"`
+	Size        string `json:"size"`
+	Description string `json:"description"`
+}
+
+func loadPresets() ([]vmSizePreset, error) {
+	var presets []vmSizePreset
+	if err := json.Unmarshal(presetCatalogJSON, &presets); err != nil {
+		return nil, fmt.Errorf("parse vm size presets: %w", err)
+	}
+	return presets, nil
+}
+
+func presetByKey(key string) (*vmSizePreset, error) {
+	presets, err := loadPresets()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range presets {
+		if p.Key == key {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown vm size preset %q", key)
+}
+
+// VMSizeByPreset resolves a named preset to a concrete VM size without
+// prompting, so e.g. `fly launch --preset small-web` can skip SelectVMSize
+// entirely.
+func VMSizeByPreset(ctx context.Context, preset string) (*api.VMSize, error) {
+	p, err := presetByKey(preset)
+	if err != nil {
+		return nil, err
+	}
+
+	vmSizes, err := client.FromContext(ctx).API().PlatformVMSizes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, vmSize := range vmSizes {
+		if vmSize.Name == p.Size {
+			return &vmSizes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("preset %q maps to vm size %q, which is not available", preset, p.Size)
+}
+
+// vmSizeArchitecture reports the CPU architecture implied by a VM size's
+// name/class. The platform doesn't expose this as a distinct field yet, so
+// it's inferred from the "arm" marker fly.io uses in both.
+func vmSizeArchitecture(vmSize api.VMSize) string {
+	if strings.Contains(strings.ToLower(vmSize.CPUClass), "arm") || strings.Contains(strings.ToLower(vmSize.Name), "arm") {
+		return "arm64"
+	}
+	return "x86_64"
+}
+
+// vmSizeHasGPU reports whether a VM size is GPU-accelerated, inferred the
+// same way as vmSizeArchitecture since the platform doesn't expose a
+// dedicated GPU field.
+func vmSizeHasGPU(vmSize api.VMSize) bool {
+	return strings.Contains(strings.ToLower(vmSize.CPUClass), "gpu") || strings.Contains(strings.ToLower(vmSize.Name), "gpu") || strings.Contains(strings.ToLower(vmSize.Name), "a100")
+}
+
+// vmSizeRow renders one VM size as a tab-separated row of CPUs, memory,
+// hourly price, architecture and GPU flag; vmSizeTable aligns a batch of
+// these into columns for display in the survey list.
+func vmSizeRow(vmSize api.VMSize) string {
+	gpu := "-"
+	if vmSizeHasGPU(vmSize) {
+		gpu = "GPU"
+	}
+	pricePerHour := vmSize.PriceSecond * 3600
+	// CPUCores is a float64 (shared-cpu sizes report fractional cores), so
+	// %.0f is correct here, not a leftover from an int -> float change.
+	return fmt.Sprintf("%s\t%.0f vCPU\t%d MB\t$%.4f/hr\t%s\t%s",
+		vmSize.Name, vmSize.CPUCores, vmSize.MemoryMB, pricePerHour, vmSizeArchitecture(vmSize), gpu)
+}
+
+func vmSizeTable(rows []string) []string {
+	var buf strings.Builder
+
+	tw := tabwriter.NewWriter(&buf, 0, 2, 2, ' ', 0)
+	for _, r := range rows {
+		fmt.Fprintln(tw, r)
+	}
+	tw.Flush()
+
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
@@ -0,0 +1,123 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/superfly/flyctl/api"
+)
+
+func TestLoadPresets(t *testing.T) {
+	presets, err := loadPresets()
+	if err != nil {
+		t.Fatalf("loadPresets() returned unexpected error: %v", err)
+	}
+	if len(presets) == 0 {
+		t.Fatal("loadPresets() returned no presets")
+	}
+
+	want := map[string]string{
+		"hobby":             "shared-cpu-1x",
+		"small-web":         "shared-cpu-2x",
+		"background-worker": "performance-2x",
+		"gpu-inference":     "a100-40gb-1x",
+	}
+	for _, p := range presets {
+		size, ok := want[p.Key]
+		if !ok {
+			t.Errorf("unexpected preset key %q", p.Key)
+			continue
+		}
+		if p.Size != size {
+			t.Errorf("preset %q maps to size %q, want %q", p.Key, p.Size, size)
+		}
+	}
+}
+
+func TestPresetByKey(t *testing.T) {
+	p, err := presetByKey("small-web")
+	if err != nil {
+		t.Fatalf("presetByKey(%q) returned unexpected error: %v", "small-web", err)
+	}
+	if p.Size != "shared-cpu-2x" {
+		t.Fatalf("presetByKey(%q).Size = %q, want %q", "small-web", p.Size, "shared-cpu-2x")
+	}
+
+	if _, err := presetByKey("does-not-exist"); err == nil {
+		t.Fatal("presetByKey(unknown) = nil error, want an error")
+	}
+}
+
+func TestVMSizeArchitecture(t *testing.T) {
+	cases := []struct {
+		name string
+		vs   api.VMSize
+		want string
+	}{
+		{"x86 shared", api.VMSize{Name: "shared-cpu-1x", CPUClass: "shared"}, "x86_64"},
+		{"arm in class", api.VMSize{Name: "shared-cpu-1x", CPUClass: "shared-arm"}, "arm64"},
+		{"arm in name", api.VMSize{Name: "shared-cpu-1x-arm", CPUClass: "shared"}, "arm64"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := vmSizeArchitecture(c.vs); got != c.want {
+				t.Errorf("vmSizeArchitecture(%+v) = %q, want %q", c.vs, got, c.want)
+			}
+		})
+	}
+}
+
+func TestVMSizeHasGPU(t *testing.T) {
+	cases := []struct {
+		name string
+		vs   api.VMSize
+		want bool
+	}{
+		{"shared cpu", api.VMSize{Name: "shared-cpu-1x", CPUClass: "shared"}, false},
+		{"a100 by name", api.VMSize{Name: "a100-40gb-1x", CPUClass: "performance"}, true},
+		{"gpu in class", api.VMSize{Name: "whatever", CPUClass: "gpu"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := vmSizeHasGPU(c.vs); got != c.want {
+				t.Errorf("vmSizeHasGPU(%+v) = %v, want %v", c.vs, got, c.want)
+			}
+		})
+	}
+}
+
+func TestVMSizeRowIncludesAllColumns(t *testing.T) {
+	vs := api.VMSize{
+		Name:        "a100-40gb-1x",
+		CPUCores:    4,
+		CPUClass:    "performance",
+		MemoryMB:    16384,
+		PriceSecond: 0.001,
+	}
+
+	row := vmSizeRow(vs)
+
+	for _, want := range []string{"a100-40gb-1x", "4 vCPU", "16384 MB", "/hr", "x86_64", "GPU"} {
+		if !strings.Contains(row, want) {
+			t.Errorf("vmSizeRow(%+v) = %q, want it to contain %q", vs, row, want)
+		}
+	}
+}
+
+func TestVMSizeTableAligns(t *testing.T) {
+	rows := []string{
+		vmSizeRow(api.VMSize{Name: "shared-cpu-1x", CPUCores: 1, MemoryMB: 256, PriceSecond: 0.0001}),
+		vmSizeRow(api.VMSize{Name: "performance-2x", CPUCores: 2, MemoryMB: 4096, PriceSecond: 0.0008}),
+	}
+
+	table := vmSizeTable(rows)
+
+	if len(table) != len(rows) {
+		t.Fatalf("vmSizeTable() returned %d rows, want %d", len(table), len(rows))
+	}
+	for _, r := range table {
+		if strings.Contains(r, "\t") {
+			t.Errorf("vmSizeTable() row %q still contains a tab, want column-aligned spaces", r)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/terminal"
@@ -19,7 +20,22 @@ import (
 	"github.com/superfly/flyctl/internal/sort"
 )
 
-func String(ctx context.Context, dst *string, msg, def string, required bool) error {
+// String prompts for a string value. key, when non-empty, is the stable
+// answer key checked against --answers-file/FLY_PROMPT_<KEY> before
+// falling back to an interactive prompt; a successful interactive answer
+// is saved back under key when --save-answers is set.
+func String(ctx context.Context, dst *string, key, msg, def string, required bool) error {
+	start := time.Now()
+
+	if v, ok := answerFor(ctx, key); ok {
+		if required && v == "" {
+			return fmt.Errorf("answer for %q is required", key)
+		}
+		*dst = v
+		recordTranscript(ctx, "string", msg, def, nil, *dst, start)
+		return nil
+	}
+
 	opt, err := newSurveyIO(ctx)
 	if err != nil {
 		return err
@@ -35,10 +51,28 @@ func String(ctx context.Context, dst *string, msg, def string, required bool) er
 		opts = append(opts, survey.WithValidator(survey.Required))
 	}
 
-	return survey.AskOne(p, dst, opts...)
+	if err := survey.AskOne(p, dst, opts...); err != nil {
+		return err
+	}
+	saveAnswer(ctx, key, *dst)
+	recordTranscript(ctx, "string", msg, def, nil, *dst, start)
+	return nil
 }
 
-func Int(ctx context.Context, dst *int, msg string, def int, required bool) error {
+// Int prompts for an integer value; see String for key/answer semantics.
+func Int(ctx context.Context, dst *int, key, msg string, def int, required bool) error {
+	start := time.Now()
+
+	if v, ok := answerFor(ctx, key); ok {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("answer for %q must be an integer: %w", key, err)
+		}
+		*dst = parsed
+		recordTranscript(ctx, "int", msg, strconv.Itoa(def), nil, strconv.Itoa(*dst), start)
+		return nil
+	}
+
 	opt, err := newSurveyIO(ctx)
 	if err != nil {
 		return err
@@ -61,10 +95,29 @@ func Int(ctx context.Context, dst *int, msg string, def int, required bool) erro
 		}
 		return nil
 	}))
-	return survey.AskOne(p, dst, opts...)
+	if err := survey.AskOne(p, dst, opts...); err != nil {
+		return err
+	}
+	saveAnswer(ctx, key, strconv.Itoa(*dst))
+	recordTranscript(ctx, "int", msg, strconv.Itoa(def), nil, strconv.Itoa(*dst), start)
+	return nil
 }
 
-func Password(ctx context.Context, dst *string, msg string, required bool) error {
+// Password prompts for a secret value; see String for key/answer
+// semantics, except that an interactively-given password is never saved
+// back to the answers file, even with --save-answers.
+func Password(ctx context.Context, dst *string, key, msg string, required bool) error {
+	start := time.Now()
+
+	if v, ok := answerFor(ctx, key); ok {
+		if required && v == "" {
+			return fmt.Errorf("answer for %q is required", key)
+		}
+		*dst = v
+		recordTranscript(ctx, "password", msg, "", nil, *dst, start)
+		return nil
+	}
+
 	opt, err := newSurveyIO(ctx)
 	if err != nil {
 		return err
@@ -79,49 +132,51 @@ func Password(ctx context.Context, dst *string, msg string, required bool) error
 		opts = append(opts, survey.WithValidator(survey.Required))
 	}
 
-	return survey.AskOne(p, dst, opts...)
-}
-
-func MultiSelect(ctx context.Context, indices *[]int, msg string, def []int, options ...string) error {
-	opt, err := newSurveyIO(ctx)
-	if err != nil {
+	if err := survey.AskOne(p, dst, opts...); err != nil {
 		return err
 	}
+	recordTranscript(ctx, "password", msg, "", nil, *dst, start)
+	return nil
+}
 
-	p := &survey.MultiSelect{
-		Message:  msg,
-		Options:  options,
-		PageSize: 15,
-		Default:  def,
+func MultiSelect(ctx context.Context, indices *[]int, msg string, def []int, options ...string) error {
+	cfg := &SelectConfig{Message: msg}
+	for _, o := range options {
+		cfg.Options = append(cfg.Options, SelectOption{Label: o})
 	}
 
-	return survey.AskOne(p, indices, opt)
+	return FuzzyMultiSelect(ctx, indices, cfg, def)
 }
 
+// Select preserves the original variadic-string signature as a thin wrapper
+// around FuzzySelect/SelectConfig; callers that need SearchKeys or per-option
+// help text should build a SelectConfig and call FuzzySelect directly.
 func Select(ctx context.Context, index *int, msg, def string, options ...string) error {
-	opt, err := newSurveyIO(ctx)
-	if err != nil {
-		return err
+	cfg := &SelectConfig{Message: msg, Default: def}
+	for _, o := range options {
+		cfg.Options = append(cfg.Options, SelectOption{Label: o})
 	}
 
-	p := &survey.Select{
-		Message:  msg,
-		Options:  options,
-		PageSize: 15,
-	}
-
-	if def != "" {
-		p.Default = def
-	}
-
-	return survey.AskOne(p, index, opt)
+	return FuzzySelect(ctx, index, cfg)
 }
 
 func Confirmf(ctx context.Context, format string, a ...interface{}) (bool, error) {
-	return Confirm(ctx, fmt.Sprintf(format, a...))
+	return Confirm(ctx, "", fmt.Sprintf(format, a...))
 }
 
-func Confirm(ctx context.Context, message string) (confirm bool, err error) {
+// Confirm asks a yes/no question; see String for key/answer semantics.
+// Pass an empty key to always prompt interactively, as before.
+func Confirm(ctx context.Context, key, message string) (confirm bool, err error) {
+	start := time.Now()
+
+	if v, ok := answerFor(ctx, key); ok {
+		confirm, err = strconv.ParseBool(v)
+		if err == nil {
+			recordTranscript(ctx, "confirm", message, "", nil, strconv.FormatBool(confirm), start)
+		}
+		return
+	}
+
 	var opt survey.AskOpt
 	if opt, err = newSurveyIO(ctx); err != nil {
 		return
@@ -131,7 +186,11 @@ func Confirm(ctx context.Context, message string) (confirm bool, err error) {
 		Message: message,
 	}
 
-	err = survey.AskOne(prompt, &confirm, opt)
+	if err = survey.AskOne(prompt, &confirm, opt); err != nil {
+		return
+	}
+	saveAnswer(ctx, key, strconv.FormatBool(confirm))
+	recordTranscript(ctx, "confirm", message, "", nil, strconv.FormatBool(confirm), start)
 
 	return
 }
@@ -219,17 +278,20 @@ func Org(ctx context.Context) (*api.Organization, error) {
 }
 
 func SelectOrg(ctx context.Context, orgs []api.Organization) (org *api.Organization, err error) {
-	var options []string
+	cfg := &SelectConfig{Message: "Select Organization:"}
 	for _, org := range orgs {
 		personalCallout := ""
 		if org.Type == "PERSONAL" && org.Slug != "personal" {
 			personalCallout = " [personal]"
 		}
-		options = append(options, fmt.Sprintf("%s (%s)%s", org.Name, org.Slug, personalCallout))
+		cfg.Options = append(cfg.Options, SelectOption{
+			Label:      fmt.Sprintf("%s (%s)%s", org.Name, org.Slug, personalCallout),
+			SearchKeys: []string{org.Slug, org.Name},
+		})
 	}
 
 	var index int
-	if err = Select(ctx, &index, "Select Organization:", "", options...); err == nil {
+	if err = FuzzySelect(ctx, &index, cfg); err == nil {
 		org = &orgs[index]
 	}
 
@@ -308,56 +370,70 @@ func Region(ctx context.Context, msg string) (*api.Region, error) {
 	}
 }
 
+// SelectRegion prompts for a single region. survey has no notion of a
+// disabled row, so a continent header (see buildRegionOptions) is still
+// selectable; rather than bubble that up as a prompt-level error, re-prompt
+// with a message explaining why nothing was chosen.
 func SelectRegion(ctx context.Context, msg string, regions []api.Region, defaultCode string) (region *api.Region, err error) {
-	var defaultOption string
-
-	var options []string
-	for _, r := range regions {
-		option := fmt.Sprintf("%s (%s)", r.Name, r.Code)
-		if r.Code == defaultCode {
-			defaultOption = option
-		}
-
-		options = append(options, option)
-	}
+	cfg, ordered, optionIdx := buildRegionOptions(ctx, regions, defaultCode)
 
 	if msg == "" {
 		msg = "Select regions:"
 	}
+	cfg.Message = msg
 
-	var index int
-	if err = Select(ctx, &index, msg, defaultOption, options...); err == nil {
-		region = &regions[index]
-	}
+	io := iostreams.FromContext(ctx)
 
-	return
+	for {
+		var index int
+		if err = FuzzySelect(ctx, &index, cfg); err != nil {
+			return nil, err
+		}
+		if index >= len(optionIdx) || optionIdx[index] < 0 {
+			fmt.Fprintln(io.ErrOut, "that's a continent header, not a region -- pick one of the regions listed under it")
+			continue
+		}
+
+		return &ordered[optionIdx[index]], nil
+	}
 }
 
+// MultiSelectRegion prompts for zero or more regions. Checking a continent
+// header box (see buildRegionOptions) is harmless -- it just doesn't map to
+// a region -- but silently dropping it leaves the user unsure why a row
+// they checked didn't show up, so each dropped header is reported.
 func MultiSelectRegion(ctx context.Context, msg string, regions []api.Region, currentRegions []string, excludeRegion string) (selectedRegions []api.Region, err error) {
-	var options []string
-
 	includedRegions := lo.Filter(regions, func(r api.Region, _ int) bool {
 		return r.Code != excludeRegion
 	})
 
-	var currentIndices []int
-	var indices []int
+	cfg, ordered, optionIdx := buildRegionOptions(ctx, includedRegions, "")
 
-	for i, r := range includedRegions {
-		if lo.Contains(currentRegions, r.Code) {
-			currentIndices = append(currentIndices, i)
+	var currentIndices []int
+	for optIdx, regionIdx := range optionIdx {
+		if regionIdx < 0 {
+			continue
+		}
+		if lo.Contains(currentRegions, ordered[regionIdx].Code) {
+			currentIndices = append(currentIndices, optIdx)
 		}
-		option := fmt.Sprintf("%s (%s)", r.Name, r.Code)
-		options = append(options, option)
 	}
 
 	if msg == "" {
 		msg = "Select regions:"
 	}
+	cfg.Message = msg
+
+	io := iostreams.FromContext(ctx)
 
-	if err = MultiSelect(ctx, &indices, msg, currentIndices, options...); err == nil {
+	var indices []int
+	if err = FuzzyMultiSelect(ctx, &indices, cfg, currentIndices); err == nil {
 		for _, index := range indices {
-			selectedRegions = append(selectedRegions, includedRegions[index])
+			if optionIdx[index] >= 0 {
+				selectedRegions = append(selectedRegions, ordered[optionIdx[index]])
+			} else {
+				fmt.Fprintln(io.ErrOut, "ignored a checked continent header; it isn't a region")
+			}
 		}
 	}
 	return
@@ -395,17 +471,58 @@ func VMSize(ctx context.Context, def string) (size *api.VMSize, err error) {
 	}
 }
 
+// vmSizeChoice is a row in the SelectVMSize list: either a preset (which
+// resolves to a concrete size by name) or a concrete size itself.
+type vmSizeChoice struct {
+	presetSize string
+	vmSize     *api.VMSize
+}
+
 func SelectVMSize(ctx context.Context, vmSizes []api.VMSize) (vmSize *api.VMSize, err error) {
-	options := []string{}
+	cfg := &SelectConfig{Message: "Select VM size:"}
+	var choices []vmSizeChoice
 
-	for _, vmSize := range vmSizes {
-		options = append(options, fmt.Sprintf("%s - %d", vmSize.Name, vmSize.MemoryMB))
+	presets, err := loadPresets()
+	if err != nil {
+		presets = nil
+	}
+	for _, p := range presets {
+		cfg.Options = append(cfg.Options, SelectOption{
+			Label:      fmt.Sprintf("%s — %s", p.Label, p.Size),
+			Help:       p.Description,
+			SearchKeys: []string{p.Key, p.Label},
+		})
+		choices = append(choices, vmSizeChoice{presetSize: p.Size})
 	}
 
-	var index int
+	rows := make([]string, len(vmSizes))
+	for i, vs := range vmSizes {
+		rows[i] = vmSizeRow(vs)
+	}
+	table := vmSizeTable(rows)
+
+	for i := range vmSizes {
+		cfg.Options = append(cfg.Options, SelectOption{
+			Label:      table[i],
+			SearchKeys: []string{vmSizes[i].Name},
+		})
+		choices = append(choices, vmSizeChoice{vmSize: &vmSizes[i]})
+	}
 
-	if err := Select(ctx, &index, "Select VM size:", "", options...); err != nil {
+	var index int
+	if err := FuzzySelect(ctx, &index, cfg); err != nil {
 		return nil, err
 	}
-	return &vmSizes[index], nil
+
+	choice := choices[index]
+	if choice.vmSize != nil {
+		return choice.vmSize, nil
+	}
+
+	for i := range vmSizes {
+		if vmSizes[i].Name == choice.presetSize {
+			return &vmSizes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("preset maps to vm size %q, which is not available", choice.presetSize)
 }
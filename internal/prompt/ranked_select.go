@@ -0,0 +1,228 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// rankedSelect is a survey.Prompt that looks and behaves like survey.Select,
+// except it owns its own option list instead of delegating filtering to
+// survey.Select.Filter. survey.Select.Filter is a boolean include/exclude
+// predicate that preserves the original option order (see fuzzyFilter), so
+// it can never deliver the "ranked by match score" behavior FuzzySelect
+// promises; rankedSelect re-sorts the matching rows by fuzzyScore on every
+// keystroke instead.
+type rankedSelect struct {
+	survey.Renderer
+
+	cfg           *SelectConfig
+	filter        string
+	selectedIndex int
+	showingHelp   bool
+}
+
+func newRankedSelect(cfg *SelectConfig) *rankedSelect {
+	return &rankedSelect{cfg: cfg}
+}
+
+// rankedOptions returns the options matching the current filter as
+// core.OptionAnswer values carrying their original cfg.Options index,
+// sorted by fuzzyScore (tightest match first). An empty filter matches
+// everything in its original order.
+func (s *rankedSelect) rankedOptions() []core.OptionAnswer {
+	type match struct {
+		index int
+		score int
+	}
+
+	var matches []match
+	for i, opt := range s.cfg.Options {
+		if s.filter == "" {
+			matches = append(matches, match{index: i})
+			continue
+		}
+
+		best := -1
+		candidates := append([]string{opt.Label}, opt.SearchKeys...)
+		for _, c := range candidates {
+			if score := fuzzyScore(s.filter, c); score >= 0 && (best < 0 || score < best) {
+				best = score
+			}
+		}
+		if best >= 0 {
+			matches = append(matches, match{index: i, score: best})
+		}
+	}
+
+	if s.filter != "" {
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+	}
+
+	answers := make([]core.OptionAnswer, len(matches))
+	for i, m := range matches {
+		answers[i] = core.OptionAnswer{Index: m.index, Value: s.cfg.Options[m.index].Label}
+	}
+	return answers
+}
+
+// rankedPage slices options down to pageSize around sel, the same way
+// survey's internal paginate does.
+func rankedPage(pageSize int, options []core.OptionAnswer, sel int) ([]core.OptionAnswer, int) {
+	if len(options) <= pageSize {
+		return options, sel
+	}
+
+	half := pageSize / 2
+	switch {
+	case sel < half:
+		return options[:pageSize], sel
+	case len(options)-sel-1 < half:
+		start := len(options) - pageSize
+		return options[start:], sel - start
+	default:
+		start := sel - half
+		return options[start : start+pageSize], half
+	}
+}
+
+func (s *rankedSelect) render(config *survey.PromptConfig) error {
+	options := s.rankedOptions()
+	pageSize := s.cfg.pageSize()
+	opts, idx := rankedPage(pageSize, options, s.selectedIndex)
+
+	filterMessage := ""
+	if s.filter != "" {
+		filterMessage = " " + s.filter
+	}
+
+	tmplData := survey.SelectTemplateData{
+		Select: survey.Select{
+			Message:       s.cfg.Message,
+			FilterMessage: filterMessage,
+		},
+		SelectedIndex: idx,
+		ShowHelp:      s.showingHelp,
+		Description: func(value string, _ int) string {
+			return s.cfg.helpFor(value)
+		},
+		PageEntries: opts,
+		Config:      config,
+	}
+
+	return s.RenderWithCursorOffset(survey.SelectQuestionTemplate, tmplData, opts, idx)
+}
+
+// defaultIndexFor finds cfg.Options' index matching def, mirroring
+// survey.Select.Prompt's own validation: an unmatched default is a config
+// error (a stale or typo'd default), not something to fail open on.
+func defaultIndexFor(options []SelectOption, def string) (int, error) {
+	for i, opt := range options {
+		if opt.Label == def {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("default value %q not found in options", def)
+}
+
+func (s *rankedSelect) Prompt(config *survey.PromptConfig) (interface{}, error) {
+	if len(s.cfg.Options) == 0 {
+		return nil, errors.New("please provide options to select from")
+	}
+	if s.cfg.Default != "" {
+		idx, err := defaultIndexFor(s.cfg.Options, s.cfg.Default)
+		if err != nil {
+			return nil, err
+		}
+		s.selectedIndex = idx
+	}
+
+	if err := s.render(config); err != nil {
+		return nil, err
+	}
+
+	cursor := s.NewCursor()
+	cursor.Save()
+	cursor.Hide()
+	defer cursor.Show()
+	defer cursor.Restore()
+
+	rr := s.NewRuneReader()
+	_ = rr.SetTermMode()
+	defer func() { _ = rr.RestoreTermMode() }()
+
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return nil, err
+		}
+		if r == terminal.KeyInterrupt {
+			return nil, terminal.InterruptErr
+		}
+		if r == terminal.KeyEndTransmission {
+			break
+		}
+
+		options := s.rankedOptions()
+		switch {
+		case r == terminal.KeyEnter || r == '\n':
+			if len(options) > 0 && s.selectedIndex < len(options) {
+				options = s.rankedOptions()
+				return options[s.selectedIndex], nil
+			}
+		case r == terminal.KeyArrowUp:
+			if len(options) > 0 {
+				if s.selectedIndex == 0 {
+					s.selectedIndex = len(options) - 1
+				} else {
+					s.selectedIndex--
+				}
+			}
+		case r == terminal.KeyTab || r == terminal.KeyArrowDown:
+			if len(options) > 0 {
+				s.selectedIndex = (s.selectedIndex + 1) % len(options)
+			}
+		case r == terminal.KeyDeleteWord || r == terminal.KeyDeleteLine:
+			s.filter = ""
+		case r == terminal.KeyDelete || r == terminal.KeyBackspace:
+			if s.filter != "" {
+				runes := []rune(s.filter)
+				s.filter = string(runes[:len(runes)-1])
+			}
+		case r >= terminal.KeySpace:
+			s.filter += string(r)
+		}
+
+		if reranked := s.rankedOptions(); len(reranked) > 0 && s.selectedIndex >= len(reranked) {
+			s.selectedIndex = len(reranked) - 1
+		}
+
+		if err := s.render(config); err != nil {
+			return nil, err
+		}
+	}
+
+	options := s.rankedOptions()
+	if len(options) == 0 {
+		return nil, errors.New("no matching options")
+	}
+	if s.selectedIndex >= len(options) {
+		s.selectedIndex = len(options) - 1
+	}
+	return options[s.selectedIndex], nil
+}
+
+func (s *rankedSelect) Cleanup(config *survey.PromptConfig, val interface{}) error {
+	cursor := s.NewCursor()
+	cursor.Restore()
+	return s.Render(survey.SelectQuestionTemplate, survey.SelectTemplateData{
+		Select:     survey.Select{Message: s.cfg.Message},
+		Answer:     val.(core.OptionAnswer).Value,
+		ShowAnswer: true,
+		Config:     config,
+	})
+}
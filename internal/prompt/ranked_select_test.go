@@ -0,0 +1,112 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRankedOptionsNoFilterPreservesOrder(t *testing.T) {
+	cfg := &SelectConfig{
+		Options: []SelectOption{
+			{Label: "Chicago (ord)", SearchKeys: []string{"ord"}},
+			{Label: "Amsterdam (ams)", SearchKeys: []string{"ams"}},
+			{Label: "Sydney (syd)", SearchKeys: []string{"syd"}},
+		},
+	}
+	s := newRankedSelect(cfg)
+
+	got := s.rankedOptions()
+	if len(got) != len(cfg.Options) {
+		t.Fatalf("rankedOptions() returned %d options, want %d", len(got), len(cfg.Options))
+	}
+	for i, opt := range got {
+		if opt.Value != cfg.Options[i].Label {
+			t.Errorf("rankedOptions()[%d] = %q, want %q", i, opt.Value, cfg.Options[i].Label)
+		}
+		if opt.Index != i {
+			t.Errorf("rankedOptions()[%d].Index = %d, want %d", i, opt.Index, i)
+		}
+	}
+}
+
+func TestRankedOptionsSortsByScore(t *testing.T) {
+	cfg := &SelectConfig{
+		Options: []SelectOption{
+			{Label: "o-r-d-city"}, // loose subsequence match for "ord", listed first
+			{Label: "ord-city"},   // tight contiguous match for "ord", listed second
+			{Label: "amsterdam"},  // no match at all
+		},
+	}
+	s := newRankedSelect(cfg)
+	s.filter = "ord"
+
+	got := s.rankedOptions()
+	if len(got) != 2 {
+		t.Fatalf("rankedOptions() returned %d options, want 2 (non-matching option should be dropped)", len(got))
+	}
+	if got[0].Value != "ord-city" {
+		t.Errorf("rankedOptions()[0] = %q, want the tighter match %q ranked first", got[0].Value, "ord-city")
+	}
+	if got[1].Value != "o-r-d-city" {
+		t.Errorf("rankedOptions()[1] = %q, want the looser match %q ranked second", got[1].Value, "o-r-d-city")
+	}
+}
+
+func TestRankedOptionsMatchesSearchKeys(t *testing.T) {
+	cfg := &SelectConfig{
+		Options: []SelectOption{
+			{Label: "Chicago", SearchKeys: []string{"ord"}},
+			{Label: "Amsterdam", SearchKeys: []string{"ams"}},
+		},
+	}
+	s := newRankedSelect(cfg)
+	s.filter = "ord"
+
+	got := s.rankedOptions()
+	if len(got) != 1 || got[0].Value != "Chicago" {
+		t.Fatalf("rankedOptions() = %+v, want only Chicago matched via its SearchKeys", got)
+	}
+}
+
+func TestRankedPage(t *testing.T) {
+	cfg := &SelectConfig{}
+	for i := 0; i < 20; i++ {
+		cfg.Options = append(cfg.Options, SelectOption{Label: string(rune('a' + i))})
+	}
+	s := newRankedSelect(cfg)
+
+	all := s.rankedOptions()
+	page, idx := rankedPage(5, all, 0)
+	if len(page) != 5 || idx != 0 {
+		t.Fatalf("rankedPage(5, all, 0) = (%d items, idx %d), want (5, 0)", len(page), idx)
+	}
+
+	page, idx = rankedPage(5, all, 19)
+	if len(page) != 5 || idx != 4 {
+		t.Fatalf("rankedPage(5, all, 19) = (%d items, idx %d), want (5, 4)", len(page), idx)
+	}
+}
+
+func TestDefaultIndexForFindsMatchingOption(t *testing.T) {
+	options := []SelectOption{{Label: "Chicago (ord)"}, {Label: "Amsterdam (ams)"}}
+
+	idx, err := defaultIndexFor(options, "Amsterdam (ams)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx != 1 {
+		t.Fatalf("defaultIndexFor() = %d, want 1", idx)
+	}
+}
+
+func TestDefaultIndexForRejectsUnmatchedDefault(t *testing.T) {
+	options := []SelectOption{{Label: "Chicago (ord)"}, {Label: "Amsterdam (ams)"}}
+
+	_, err := defaultIndexFor(options, "Sydney (syd)")
+	if err == nil {
+		t.Fatal("expected an error for a default that doesn't match any option")
+	}
+	if !strings.Contains(err.Error(), "not found in options") {
+		t.Fatalf("error = %q, want it to mention the default isn't found in options", err.Error())
+	}
+}
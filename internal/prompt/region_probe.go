@@ -0,0 +1,231 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/superfly/flyctl/api"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+const (
+	probeWorkers = 8
+	probeTimeout = 1500 * time.Millisecond
+	probeSamples = 3
+)
+
+// probeCache holds per-process RTT results so repeated Region() calls in
+// one flyctl invocation don't re-probe the same region.
+var (
+	probeCacheMu sync.Mutex
+	probeCache   = map[string]time.Duration{}
+)
+
+// regionContinents maps region codes to a continent label for grouping.
+// Codes not listed here fall back to "Other".
+var regionContinents = map[string]string{
+	"ams": "Europe", "cdg": "Europe", "fra": "Europe", "lhr": "Europe",
+	"mad": "Europe", "waw": "Europe", "arn": "Europe",
+	"iad": "North America", "ord": "North America", "dfw": "North America",
+	"sjc": "North America", "lax": "North America", "sea": "North America",
+	"yyz": "North America", "ewr": "North America", "den": "North America",
+	"bom": "Asia", "hkg": "Asia", "nrt": "Asia", "sin": "Asia",
+	"syd": "Oceania",
+	"gru": "South America", "scl": "South America", "eze": "South America",
+	"jnb": "Africa",
+}
+
+func continentFor(code string) string {
+	if c, ok := regionContinents[code]; ok {
+		return c
+	}
+	return "Other"
+}
+
+// probeRegionLatency issues probeSamples HEAD requests to the region's
+// public edge and returns the median RTT.
+func probeRegionLatency(ctx context.Context, code string) (time.Duration, error) {
+	client := &http.Client{Timeout: probeTimeout}
+
+	samples := make([]time.Duration, 0, probeSamples)
+	for i := 0; i < probeSamples; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, fmt.Sprintf("https://%s.fly.dev/", code), nil)
+		if err != nil {
+			return 0, err
+		}
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+
+		samples = append(samples, time.Since(start))
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples[len(samples)/2], nil
+}
+
+// probeRegions probes every region concurrently, bounded by probeWorkers,
+// in a context derived from ctx so Ctrl-C cancels outstanding probes.
+// Regions that fail to probe are simply omitted from the result so the
+// caller can fall back to alphabetical order for them.
+func probeRegions(ctx context.Context, regions []api.Region) map[string]time.Duration {
+	results := make(map[string]time.Duration, len(regions))
+
+	var toProbe []api.Region
+	probeCacheMu.Lock()
+	for _, r := range regions {
+		if rtt, ok := probeCache[r.Code]; ok {
+			results[r.Code] = rtt
+		} else {
+			toProbe = append(toProbe, r)
+		}
+	}
+	probeCacheMu.Unlock()
+
+	if len(toProbe) == 0 {
+		return results
+	}
+
+	sem := make(chan struct{}, probeWorkers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for _, r := range toProbe {
+		r := r
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			rtt, err := probeRegionLatency(ctx, r.Code)
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			results[r.Code] = rtt
+			mu.Unlock()
+
+			probeCacheMu.Lock()
+			probeCache[r.Code] = rtt
+			probeCacheMu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// buildRegionOptions builds the SelectConfig for a region prompt. When
+// --probe-regions is set it probes every region's latency, re-sorts
+// ascending by RTT, annotates each label with "Name (code) — 42ms", and
+// groups options under continent header rows; if every probe fails (so
+// there's no RTT to group or sort by) it falls back silently to the same
+// flat, header-less ordering used when --probe-regions is off. optionIdx
+// maps each cfg.Options entry back to an index in ordered, or -1 for header
+// rows. survey has no concept of a disabled row, so header rows remain
+// selectable at this layer -- SelectRegion/MultiSelectRegion handle a
+// header hit themselves.
+func buildRegionOptions(ctx context.Context, regions []api.Region, defaultCode string) (cfg *SelectConfig, ordered []api.Region, optionIdx []int) {
+	ordered = append(ordered, regions...)
+
+	var rtts map[string]time.Duration
+	probing := config.FromContext(ctx).ProbeRegions
+	if probing {
+		rtts = probeRegions(ctx, ordered)
+	}
+	// Only group by continent if at least one probe actually succeeded;
+	// if every probe failed, rtts is empty and there's nothing meaningful
+	// to group or sort by, so fall back to the same flat, header-less
+	// ordering used when --probe-regions is off entirely.
+	grouping := probing && len(rtts) > 0
+	if grouping {
+		// Rank continents by their own best (lowest) RTT so the groups come
+		// out nearest-first, then sort within each continent by RTT. Sorting
+		// by RTT alone, without this continent grouping pass, interleaves
+		// regions from different continents whenever their RTTs overlap.
+		continentBestRTT := map[string]time.Duration{}
+		for _, r := range ordered {
+			rtt, ok := rtts[r.Code]
+			if !ok {
+				continue
+			}
+			continent := continentFor(r.Code)
+			if best, ok := continentBestRTT[continent]; !ok || rtt < best {
+				continentBestRTT[continent] = rtt
+			}
+		}
+
+		sort.SliceStable(ordered, func(i, j int) bool {
+			ci, cj := continentFor(ordered[i].Code), continentFor(ordered[j].Code)
+			if ci != cj {
+				bi, iok := continentBestRTT[ci]
+				bj, jok := continentBestRTT[cj]
+				switch {
+				case iok && jok:
+					return bi < bj
+				case iok:
+					return true
+				case jok:
+					return false
+				default:
+					return ci < cj
+				}
+			}
+
+			ri, iok := rtts[ordered[i].Code]
+			rj, jok := rtts[ordered[j].Code]
+			switch {
+			case iok && jok:
+				return ri < rj
+			case iok:
+				return true
+			case jok:
+				return false
+			default:
+				return ordered[i].Name < ordered[j].Name
+			}
+		})
+	}
+
+	cfg = &SelectConfig{}
+
+	lastContinent := ""
+	for i, r := range ordered {
+		if grouping {
+			continent := continentFor(r.Code)
+			if continent != lastContinent {
+				cfg.Options = append(cfg.Options, SelectOption{Label: fmt.Sprintf("── %s ──", continent)})
+				optionIdx = append(optionIdx, -1)
+				lastContinent = continent
+			}
+		}
+
+		label := fmt.Sprintf("%s (%s)", r.Name, r.Code)
+		if rtt, ok := rtts[r.Code]; ok {
+			label = fmt.Sprintf("%s — %dms", label, rtt.Milliseconds())
+		}
+
+		cfg.Options = append(cfg.Options, SelectOption{
+			Label:      label,
+			SearchKeys: []string{r.Code, r.Name},
+		})
+		optionIdx = append(optionIdx, i)
+
+		if r.Code == defaultCode {
+			cfg.Default = label
+		}
+	}
+
+	return cfg, ordered, optionIdx
+}
@@ -0,0 +1,136 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/superfly/flyctl/api"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+func TestContinentFor(t *testing.T) {
+	cases := map[string]string{
+		"ord": "North America",
+		"fra": "Europe",
+		"syd": "Oceania",
+		"jnb": "Africa",
+		"zzz": "Other",
+	}
+	for code, want := range cases {
+		if got := continentFor(code); got != want {
+			t.Errorf("continentFor(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestBuildRegionOptionsWithoutProbingHasNoHeaders(t *testing.T) {
+	regions := []api.Region{
+		{Code: "ord", Name: "Chicago"},
+		{Code: "fra", Name: "Frankfurt"},
+	}
+
+	cfg, ordered, optionIdx := buildRegionOptions(context.Background(), regions, "fra")
+
+	if len(cfg.Options) != len(regions) {
+		t.Fatalf("got %d options, want %d (no continent headers without --probe-regions)", len(cfg.Options), len(regions))
+	}
+	for i, idx := range optionIdx {
+		if idx < 0 {
+			t.Fatalf("optionIdx[%d] = %d, want a non-negative region index", i, idx)
+		}
+		if ordered[idx].Code != regions[i].Code {
+			t.Fatalf("optionIdx[%d] maps to %q, want %q", i, ordered[idx].Code, regions[i].Code)
+		}
+	}
+	if cfg.Default == "" {
+		t.Error("expected the default region's label to be set as cfg.Default")
+	}
+}
+
+func TestBuildRegionOptionsWithProbingGroupsByContinent(t *testing.T) {
+	// iad/yyz/ord are North America, lhr/ams/fra are Europe. RTT alone
+	// interleaves them (iad=15 < yyz=18 < lhr=20 < ams=22 < fra=23 < ord=25);
+	// grouping by continent first must keep each continent's regions
+	// together regardless.
+	regions := []api.Region{
+		{Code: "iad", Name: "Ashburn"},
+		{Code: "yyz", Name: "Toronto"},
+		{Code: "lhr", Name: "London"},
+		{Code: "ams", Name: "Amsterdam"},
+		{Code: "fra", Name: "Frankfurt"},
+		{Code: "ord", Name: "Chicago"},
+	}
+	rtts := map[string]time.Duration{
+		"iad": 15 * time.Millisecond,
+		"yyz": 18 * time.Millisecond,
+		"lhr": 20 * time.Millisecond,
+		"ams": 22 * time.Millisecond,
+		"fra": 23 * time.Millisecond,
+		"ord": 25 * time.Millisecond,
+	}
+
+	probeCacheMu.Lock()
+	for code, rtt := range rtts {
+		probeCache[code] = rtt
+	}
+	probeCacheMu.Unlock()
+	t.Cleanup(func() {
+		probeCacheMu.Lock()
+		for code := range rtts {
+			delete(probeCache, code)
+		}
+		probeCacheMu.Unlock()
+	})
+
+	ctx := config.NewContext(context.Background(), &config.Config{ProbeRegions: true})
+	cfg, ordered, optionIdx := buildRegionOptions(ctx, regions, "")
+
+	var headers []string
+	var codesByHeader []string
+	for i, idx := range optionIdx {
+		if idx < 0 {
+			headers = append(headers, cfg.Options[i].Label)
+			codesByHeader = append(codesByHeader, "|")
+			continue
+		}
+		codesByHeader = append(codesByHeader, ordered[idx].Code)
+	}
+
+	wantHeaders := []string{"── North America ──", "── Europe ──"}
+	if len(headers) != len(wantHeaders) {
+		t.Fatalf("got headers %v, want exactly one header per continent %v", headers, wantHeaders)
+	}
+	for i, h := range wantHeaders {
+		if headers[i] != h {
+			t.Errorf("headers[%d] = %q, want %q", i, headers[i], h)
+		}
+	}
+}
+
+func TestBuildRegionOptionsFallsBackFlatWhenAllProbesFail(t *testing.T) {
+	regions := []api.Region{
+		{Code: "dfw", Name: "Dallas"},
+		{Code: "sjc", Name: "San Jose"},
+	}
+
+	// An already-canceled context makes every probe fail immediately
+	// without touching the network, standing in for "every probe failed".
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx = config.NewContext(ctx, &config.Config{ProbeRegions: true})
+
+	cfg, ordered, optionIdx := buildRegionOptions(ctx, regions, "sjc")
+
+	if len(cfg.Options) != len(regions) {
+		t.Fatalf("got %d options, want %d (no continent headers when every probe fails)", len(cfg.Options), len(regions))
+	}
+	for i, idx := range optionIdx {
+		if idx < 0 {
+			t.Fatalf("optionIdx[%d] = %d, want a non-negative region index", i, idx)
+		}
+		if ordered[idx].Code != regions[i].Code {
+			t.Fatalf("optionIdx[%d] maps to %q, want %q", i, ordered[idx].Code, regions[i].Code)
+		}
+	}
+}
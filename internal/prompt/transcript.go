@@ -0,0 +1,95 @@
+package prompt
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+type transcriptCtxKey struct{}
+
+// WithTranscript records every prompt issued against ctx (and any context
+// derived from it) as newline-delimited JSON written to w. This lets
+// tests and wrapper tools (IDE plugins, bots) capture exactly what a user
+// was asked and what they chose.
+func WithTranscript(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, transcriptCtxKey{}, w)
+}
+
+type transcriptRecord struct {
+	Timestamp time.Time `json:"ts"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	Default   string    `json:"default,omitempty"`
+	Options   []string  `json:"options,omitempty"`
+	Answer    string    `json:"answer"`
+	ElapsedMs int64     `json:"elapsed_ms"`
+}
+
+const redactedAnswer = "[redacted]"
+
+var (
+	transcriptFileOnce sync.Once
+	transcriptFile     io.Writer
+	transcriptMu       sync.Mutex
+)
+
+// transcriptWriter resolves the transcript destination for ctx: a
+// context-scoped writer set via WithTranscript takes precedence over the
+// process-wide FLY_PROMPT_TRANSCRIPT file, which is opened once and
+// reused for the life of the process.
+func transcriptWriter(ctx context.Context) io.Writer {
+	if w, ok := ctx.Value(transcriptCtxKey{}).(io.Writer); ok {
+		return w
+	}
+
+	transcriptFileOnce.Do(func() {
+		path := os.Getenv("FLY_PROMPT_TRANSCRIPT")
+		if path == "" {
+			return
+		}
+
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return
+		}
+		transcriptFile = f
+	})
+
+	return transcriptFile
+}
+
+// recordTranscript appends a transcript entry for one prompt call, if a
+// transcript destination is configured for ctx. answer is redacted for
+// kind "password".
+func recordTranscript(ctx context.Context, kind, message, def string, options []string, answer string, start time.Time) {
+	w := transcriptWriter(ctx)
+	if w == nil {
+		return
+	}
+
+	if kind == "password" {
+		answer = redactedAnswer
+	}
+
+	data, err := json.Marshal(transcriptRecord{
+		Timestamp: start,
+		Kind:      kind,
+		Message:   message,
+		Default:   def,
+		Options:   options,
+		Answer:    answer,
+		ElapsedMs: time.Since(start).Milliseconds(),
+	})
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	transcriptMu.Lock()
+	defer transcriptMu.Unlock()
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,54 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordTranscriptWritesJSONLine(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := WithTranscript(context.Background(), &buf)
+
+	start := time.Now().Add(-5 * time.Millisecond)
+	recordTranscript(ctx, "string", "Name:", "default", []string{"a", "b"}, "answer", start)
+
+	line := strings.TrimRight(buf.String(), "\n")
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Fatalf("expected exactly one newline-terminated record, got %q", buf.String())
+	}
+
+	var rec transcriptRecord
+	if err := json.Unmarshal([]byte(line), &rec); err != nil {
+		t.Fatalf("record is not valid JSON: %v", err)
+	}
+	if rec.Kind != "string" || rec.Message != "Name:" || rec.Default != "default" || rec.Answer != "answer" {
+		t.Fatalf("unexpected record: %+v", rec)
+	}
+	if len(rec.Options) != 2 {
+		t.Fatalf("expected 2 options, got %d", len(rec.Options))
+	}
+}
+
+func TestRecordTranscriptRedactsPasswords(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := WithTranscript(context.Background(), &buf)
+
+	recordTranscript(ctx, "password", "Secret:", "", nil, "hunter2", time.Now())
+
+	var rec transcriptRecord
+	if err := json.Unmarshal(bytes.TrimRight(buf.Bytes(), "\n"), &rec); err != nil {
+		t.Fatalf("record is not valid JSON: %v", err)
+	}
+	if rec.Answer != redactedAnswer {
+		t.Fatalf("Answer = %q, want %q", rec.Answer, redactedAnswer)
+	}
+}
+
+func TestRecordTranscriptNoopWithoutDestination(t *testing.T) {
+	// No WithTranscript and no FLY_PROMPT_TRANSCRIPT: must not panic or block.
+	recordTranscript(context.Background(), "string", "Name:", "", nil, "answer", time.Now())
+}
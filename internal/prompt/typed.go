@@ -0,0 +1,294 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Validator validates a string input and returns a descriptive error when
+// it's unacceptable, or nil otherwise. It backs the typed prompt helpers
+// (Duration, ByteSize, CIDR, Email, Matching) so callers stop hand-rolling
+// Atoi-plus-unit-parsing at every call site.
+type Validator interface {
+	Validate(input string) error
+}
+
+// ValidatorFunc adapts a plain function to a Validator.
+type ValidatorFunc func(input string) error
+
+func (f ValidatorFunc) Validate(input string) error { return f(input) }
+
+// Chain combines validators so input is accepted only if it passes all of
+// them, in order; the first failure's error is returned.
+func Chain(validators ...Validator) Validator {
+	return ValidatorFunc(func(input string) error {
+		for _, v := range validators {
+			if err := v.Validate(input); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type typedOptions struct {
+	help string
+}
+
+// TypedOption customizes a typed prompt helper.
+type TypedOption func(*typedOptions)
+
+// WithHelp attaches help text surfaced via survey's "?" prompt, typically
+// showing accepted formats and examples for the expected type.
+func WithHelp(help string) TypedOption {
+	return func(o *typedOptions) { o.help = help }
+}
+
+func resolveTypedOptions(opts ...TypedOption) typedOptions {
+	var o typedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// typedPrompt is the shared String-like plumbing behind the typed prompt
+// helpers: it checks the answer source, otherwise prompts with Validator
+// wired in as a survey validator, then saves/records the raw answer the
+// same way String does.
+func typedPrompt(ctx context.Context, key, msg, def string, required bool, help string, validator Validator) (string, error) {
+	start := time.Now()
+
+	if v, ok := answerFor(ctx, key); ok {
+		if required && v == "" {
+			return "", fmt.Errorf("answer for %q is required", key)
+		}
+		if err := validator.Validate(v); err != nil {
+			return "", fmt.Errorf("answer for %q is invalid: %w", key, err)
+		}
+		recordTranscript(ctx, "string", msg, def, nil, v, start)
+		return v, nil
+	}
+
+	opt, err := newSurveyIO(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p := &survey.Input{
+		Message: msg,
+		Default: def,
+		Help:    help,
+	}
+
+	opts := []survey.AskOpt{opt}
+	if required {
+		opts = append(opts, survey.WithValidator(survey.Required))
+	}
+	opts = append(opts, survey.WithValidator(func(val interface{}) error {
+		return validator.Validate(val.(string))
+	}))
+
+	var answer string
+	if err := survey.AskOne(p, &answer, opts...); err != nil {
+		return "", err
+	}
+
+	saveAnswer(ctx, key, answer)
+	recordTranscript(ctx, "string", msg, def, nil, answer, start)
+
+	return answer, nil
+}
+
+// Duration prompts for a duration like "30s", "5m" or "2h30m".
+func Duration(ctx context.Context, dst *time.Duration, key, msg string, def time.Duration, required bool, opts ...TypedOption) error {
+	o := resolveTypedOptions(opts...)
+
+	validator := ValidatorFunc(func(input string) error {
+		if input == "" {
+			return nil
+		}
+		if _, err := time.ParseDuration(input); err != nil {
+			return errors.New("must be a duration like 30s, 5m or 2h30m")
+		}
+		return nil
+	})
+
+	var defStr string
+	if def > 0 {
+		defStr = def.String()
+	}
+
+	answer, err := typedPrompt(ctx, key, msg, defStr, required, o.help, validator)
+	if err != nil {
+		return err
+	}
+	if answer == "" {
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(answer)
+	if err != nil {
+		return err
+	}
+	*dst = parsed
+	return nil
+}
+
+var byteSizeUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1000,
+	"mb":  1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+var byteSizeRe = regexp.MustCompile(`(?i)^\s*([0-9]+(?:\.[0-9]+)?)\s*([a-z]*)\s*$`)
+
+// parseByteSize parses sizes like "512MB" or "2GiB". A bare number is
+// treated as bytes.
+func parseByteSize(s string) (uint64, error) {
+	m := byteSizeRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, errors.New("must look like 512MB or 2GiB")
+	}
+
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	mult, ok := byteSizeUnits[strings.ToLower(m[2])]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", m[2])
+	}
+
+	return uint64(value * float64(mult)), nil
+}
+
+// ByteSize prompts for a byte size like "512MB" or "2GiB".
+func ByteSize(ctx context.Context, dst *uint64, key, msg string, def uint64, required bool, opts ...TypedOption) error {
+	o := resolveTypedOptions(opts...)
+
+	validator := ValidatorFunc(func(input string) error {
+		if input == "" {
+			return nil
+		}
+		_, err := parseByteSize(input)
+		return err
+	})
+
+	var defStr string
+	if def > 0 {
+		defStr = strconv.FormatUint(def, 10)
+	}
+
+	answer, err := typedPrompt(ctx, key, msg, defStr, required, o.help, validator)
+	if err != nil {
+		return err
+	}
+	if answer == "" {
+		return nil
+	}
+
+	parsed, err := parseByteSize(answer)
+	if err != nil {
+		return err
+	}
+	*dst = parsed
+	return nil
+}
+
+// CIDR prompts for an address prefix like "10.0.0.0/24".
+func CIDR(ctx context.Context, dst *netip.Prefix, key, msg string, def netip.Prefix, required bool, opts ...TypedOption) error {
+	o := resolveTypedOptions(opts...)
+
+	validator := ValidatorFunc(func(input string) error {
+		if input == "" {
+			return nil
+		}
+		if _, err := netip.ParsePrefix(input); err != nil {
+			return errors.New("must be a CIDR like 10.0.0.0/24")
+		}
+		return nil
+	})
+
+	var defStr string
+	if def.IsValid() {
+		defStr = def.String()
+	}
+
+	answer, err := typedPrompt(ctx, key, msg, defStr, required, o.help, validator)
+	if err != nil {
+		return err
+	}
+	if answer == "" {
+		return nil
+	}
+
+	parsed, err := netip.ParsePrefix(answer)
+	if err != nil {
+		return err
+	}
+	*dst = parsed
+	return nil
+}
+
+var emailRe = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// Email prompts for an email address.
+func Email(ctx context.Context, dst *string, key, msg, def string, required bool, opts ...TypedOption) error {
+	o := resolveTypedOptions(opts...)
+
+	validator := ValidatorFunc(func(input string) error {
+		if input == "" {
+			return nil
+		}
+		if !emailRe.MatchString(input) {
+			return errors.New("must be a valid email address")
+		}
+		return nil
+	})
+
+	answer, err := typedPrompt(ctx, key, msg, def, required, o.help, validator)
+	if err != nil {
+		return err
+	}
+	*dst = answer
+	return nil
+}
+
+// Matching prompts for a string that must match re.
+func Matching(ctx context.Context, dst *string, key, msg, def string, required bool, re *regexp.Regexp, opts ...TypedOption) error {
+	o := resolveTypedOptions(opts...)
+
+	validator := ValidatorFunc(func(input string) error {
+		if input == "" {
+			return nil
+		}
+		if !re.MatchString(input) {
+			return fmt.Errorf("must match %s", re.String())
+		}
+		return nil
+	})
+
+	answer, err := typedPrompt(ctx, key, msg, def, required, o.help, validator)
+	if err != nil {
+		return err
+	}
+	*dst = answer
+	return nil
+}
@@ -0,0 +1,205 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    uint64
+		wantErr bool
+	}{
+		{in: "512", want: 512},
+		{in: "512B", want: 512},
+		{in: "512MB", want: 512 * 1000 * 1000},
+		{in: "2GiB", want: 2 * (1 << 30)},
+		{in: "1.5GB", want: uint64(1.5 * 1000 * 1000 * 1000)},
+		{in: "1TiB", want: 1 << 40},
+		{in: "  4 mb  ", want: 4 * 1000 * 1000},
+		{in: "", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "512XB", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := parseByteSize(c.in)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("parseByteSize(%q) = %d, nil; want an error", c.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseByteSize(%q) returned unexpected error: %v", c.in, err)
+			}
+			if got != c.want {
+				t.Fatalf("parseByteSize(%q) = %d, want %d", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+var errFirst = errors.New("first validator failed")
+
+func TestChainReturnsFirstFailure(t *testing.T) {
+	var calls []string
+	fails := ValidatorFunc(func(string) error {
+		calls = append(calls, "fails")
+		return errFirst
+	})
+	neverRuns := ValidatorFunc(func(string) error {
+		calls = append(calls, "neverRuns")
+		return nil
+	})
+
+	err := Chain(fails, neverRuns).Validate("x")
+	if err != errFirst {
+		t.Fatalf("Validate() = %v, want %v", err, errFirst)
+	}
+	if len(calls) != 1 || calls[0] != "fails" {
+		t.Fatalf("expected Chain to stop at the first failure, got %v", calls)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "30s", want: 30 * time.Second},
+		{in: "5m", want: 5 * time.Minute},
+		{in: "2h30m", want: 2*time.Hour + 30*time.Minute},
+		{in: "banana", wantErr: true},
+		{in: "30", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			t.Setenv("FLY_PROMPT_DURATION", c.in)
+
+			var dst time.Duration
+			err := Duration(context.Background(), &dst, "duration", "Duration:", 0, false)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("Duration(%q) = nil error, want an error", c.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Duration(%q) returned unexpected error: %v", c.in, err)
+			}
+			if dst != c.want {
+				t.Fatalf("Duration(%q) = %v, want %v", c.in, dst, c.want)
+			}
+		})
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "10.0.0.0/24", want: "10.0.0.0/24"},
+		{in: "2001:db8::/32", want: "2001:db8::/32"},
+		{in: "10.0.0.0/33", wantErr: true},
+		{in: "not-a-cidr", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			t.Setenv("FLY_PROMPT_CIDR", c.in)
+
+			var dst netip.Prefix
+			err := CIDR(context.Background(), &dst, "cidr", "CIDR:", netip.Prefix{}, false)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("CIDR(%q) = nil error, want an error", c.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CIDR(%q) returned unexpected error: %v", c.in, err)
+			}
+			if dst.String() != c.want {
+				t.Fatalf("CIDR(%q) = %v, want %v", c.in, dst, c.want)
+			}
+		})
+	}
+}
+
+func TestEmail(t *testing.T) {
+	cases := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "user@example.com"},
+		{in: "foo@bar", wantErr: true},
+		{in: "not-an-email", wantErr: true},
+		{in: "@example.com", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			t.Setenv("FLY_PROMPT_EMAIL", c.in)
+
+			var dst string
+			err := Email(context.Background(), &dst, "email", "Email:", "", false)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("Email(%q) = nil error, want an error", c.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Email(%q) returned unexpected error: %v", c.in, err)
+			}
+			if dst != c.in {
+				t.Fatalf("Email(%q) = %q, want %q", c.in, dst, c.in)
+			}
+		})
+	}
+}
+
+func TestMatching(t *testing.T) {
+	re := regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+	cases := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: "my-app-1"},
+		{in: "MyApp", wantErr: true},
+		{in: "1-my-app", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			t.Setenv("FLY_PROMPT_NAME", c.in)
+
+			var dst string
+			err := Matching(context.Background(), &dst, "name", "Name:", "", false, re)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("Matching(%q) = nil error, want an error", c.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Matching(%q) returned unexpected error: %v", c.in, err)
+			}
+			if dst != c.in {
+				t.Fatalf("Matching(%q) = %q, want %q", c.in, dst, c.in)
+			}
+		})
+	}
+}